@@ -0,0 +1,45 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+// Package blobstore provides a content-addressed object store that the CXDB
+// Go client can use to offload large blobs (fstree file contents, attached
+// payloads) to S3 or an S3-compatible service instead of shipping them
+// through the binary protocol.
+package blobstore
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned by ObjectStore.Get when no object exists for the
+// given hash.
+var ErrNotFound = errors.New("blobstore: object not found")
+
+// ObjectStore is a content-addressed blob store keyed by the blake3 hash of
+// its contents. Implementations must treat Put as idempotent: uploading the
+// same hash twice succeeds rather than returning an error, so callers can
+// always attempt the upload instead of checking first.
+type ObjectStore interface {
+	// Put uploads size bytes read from r under hash. Uploading a hash that
+	// already exists is not an error. Whether the existing object is left
+	// untouched or rewritten with the same bytes is up to the implementation;
+	// S3Store rewrites it and does not skip the write.
+	Put(ctx context.Context, hash [32]byte, r io.Reader, size int64) error
+
+	// Has reports whether an object exists for hash without fetching it.
+	Has(ctx context.Context, hash [32]byte) (bool, error)
+
+	// Get opens the object stored under hash. It returns ErrNotFound if no
+	// such object exists.
+	Get(ctx context.Context, hash [32]byte) (io.ReadCloser, error)
+}
+
+// Key returns the canonical object key for a content hash: its lowercase hex
+// encoding. Stores that namespace keys under a prefix should use this as the
+// suffix, e.g. "blobs/" + Key(hash).
+func Key(hash [32]byte) string {
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+)
+
+// S3Config describes how to reach an S3-compatible object store, covering
+// both AWS S3 and a self-hosted MinIO cluster.
+type S3Config struct {
+	// Endpoint overrides AWS's default endpoint resolution, e.g.
+	// "https://minio.internal:9000". Leave empty to use AWS's regional
+	// endpoints.
+	Endpoint string
+
+	// Region is passed through to the AWS SDK. MinIO ignores it, but the SDK
+	// requires a non-empty value regardless of target.
+	Region string
+
+	// Bucket is the bucket blobs are stored under.
+	Bucket string
+
+	// AccessKeyID and SecretAccessKey are static credentials. If both are
+	// empty, the AWS SDK's default credential chain (env vars, shared
+	// config, instance role) is used instead.
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// DisableTLS connects over plain HTTP. Only set this for local MinIO
+	// development; never in production.
+	DisableTLS bool
+
+	// UsePathStyle forces path-style addressing (bucket as a path segment
+	// rather than a subdomain), which most MinIO deployments require.
+	UsePathStyle bool
+}
+
+// S3Store is an ObjectStore backed by S3 or an S3-compatible service.
+type S3Store struct {
+	client *s3.Client
+	bucket string
+}
+
+var _ ObjectStore = (*S3Store)(nil)
+
+// NewS3Store builds an S3Store from cfg, resolving credentials and endpoint
+// the same way the AWS CLI and SDK would.
+func NewS3Store(ctx context.Context, cfg S3Config) (*S3Store, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("blobstore: S3Config.Bucket is required")
+	}
+
+	var optFns []func(*awsconfig.LoadOptions) error
+	if cfg.Region != "" {
+		optFns = append(optFns, awsconfig.WithRegion(cfg.Region))
+	}
+	if cfg.AccessKeyID != "" || cfg.SecretAccessKey != "" {
+		optFns = append(optFns, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("blobstore: load aws config: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+		o.UsePathStyle = cfg.UsePathStyle
+		o.EndpointOptions.DisableHTTPS = cfg.DisableTLS
+	})
+
+	return &S3Store{client: client, bucket: cfg.Bucket}, nil
+}
+
+// Put uploads an object under hash. Concurrent writers racing on the same
+// content hash are safe without a conditional write: the key is the content
+// hash, so a racing PutObject either hasn't landed yet (this call wins) or
+// has already written the exact same bytes (this call overwrites them with
+// themselves), never a conflicting value.
+func (s *S3Store) Put(ctx context.Context, hash [32]byte, r io.Reader, size int64) error {
+	key := Key(hash)
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		Body:          r,
+		ContentLength: aws.Int64(size),
+	})
+	return mapPutError(key, err)
+}
+
+// Has reports whether an object exists for hash.
+func (s *S3Store) Has(ctx context.Context, hash [32]byte) (bool, error) {
+	key := Key(hash)
+	_, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return mapHeadError(key, err)
+}
+
+// Get opens the object stored under hash.
+func (s *S3Store) Get(ctx context.Context, hash [32]byte) (io.ReadCloser, error) {
+	key := Key(hash)
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, mapGetError(key, err)
+	}
+	return out.Body, nil
+}
+
+// mapPutError translates a PutObject error onto Put's idempotent-upload
+// contract. Put sends no conditional-write header, but some
+// S3-compatible services enforce their own conditional checks on
+// content-addressed keys; if one rejects a write as a precondition
+// conflict, that still means another writer already uploaded this content
+// hash, which is success, not failure.
+func mapPutError(key string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "PreconditionFailed", "ConditionalRequestConflict":
+			return nil
+		}
+	}
+	return fmt.Errorf("blobstore: put %s: %w", key, err)
+}
+
+// mapHeadError translates a HeadObject error onto Has's bool-report
+// contract: a missing object is a false result, not an error.
+func mapHeadError(key string, err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NotFound" {
+		return false, nil
+	}
+	return false, fmt.Errorf("blobstore: head %s: %w", key, err)
+}
+
+// mapGetError translates a GetObject error onto Get's ErrNotFound contract.
+func mapGetError(key string, err error) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return fmt.Errorf("blobstore: get %s: %w", key, err)
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package blobstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+// fakeAPIError implements smithy.APIError without depending on the SDK
+// actually making a request, so the error-mapping logic can be tested in
+// isolation from S3Store's network calls.
+type fakeAPIError struct {
+	code string
+}
+
+func (e *fakeAPIError) Error() string        { return "fake api error: " + e.code }
+func (e *fakeAPIError) ErrorCode() string    { return e.code }
+func (e *fakeAPIError) ErrorMessage() string { return e.code }
+func (e *fakeAPIError) ErrorFault() smithy.ErrorFault {
+	return smithy.FaultUnknown
+}
+
+var _ smithy.APIError = (*fakeAPIError)(nil)
+
+func TestMapPutErrorTreatsConditionalWriteRejectionAsSuccess(t *testing.T) {
+	cases := []string{"PreconditionFailed", "ConditionalRequestConflict"}
+	for _, code := range cases {
+		if err := mapPutError("deadbeef", &fakeAPIError{code: code}); err != nil {
+			t.Errorf("mapPutError(%q) = %v, want nil (another writer already has this content)", code, err)
+		}
+	}
+}
+
+func TestMapPutErrorWrapsOtherFailures(t *testing.T) {
+	if err := mapPutError("deadbeef", &fakeAPIError{code: "AccessDenied"}); err == nil {
+		t.Fatal("expected AccessDenied to be returned as an error")
+	}
+
+	underlying := errors.New("network is down")
+	err := mapPutError("deadbeef", underlying)
+	if err == nil || !errors.Is(err, underlying) {
+		t.Fatalf("mapPutError should wrap non-API errors with %%w, got %v", err)
+	}
+}
+
+func TestMapPutErrorNilIsNil(t *testing.T) {
+	if err := mapPutError("deadbeef", nil); err != nil {
+		t.Fatalf("mapPutError(nil) = %v, want nil", err)
+	}
+}
+
+func TestMapHeadErrorNotFoundReportsFalse(t *testing.T) {
+	ok, err := mapHeadError("deadbeef", &fakeAPIError{code: "NotFound"})
+	if err != nil {
+		t.Fatalf("mapHeadError(NotFound) returned error %v, want nil", err)
+	}
+	if ok {
+		t.Fatal("mapHeadError(NotFound) = true, want false")
+	}
+}
+
+func TestMapHeadErrorOtherFailuresPropagate(t *testing.T) {
+	ok, err := mapHeadError("deadbeef", &fakeAPIError{code: "AccessDenied"})
+	if err == nil {
+		t.Fatal("expected AccessDenied to be returned as an error")
+	}
+	if ok {
+		t.Fatal("mapHeadError should report false alongside a propagated error")
+	}
+}
+
+func TestMapHeadErrorNilMeansPresent(t *testing.T) {
+	ok, err := mapHeadError("deadbeef", nil)
+	if err != nil || !ok {
+		t.Fatalf("mapHeadError(nil) = (%v, %v), want (true, nil)", ok, err)
+	}
+}
+
+func TestMapGetErrorNoSuchKeyReturnsErrNotFound(t *testing.T) {
+	err := mapGetError("deadbeef", &fakeAPIError{code: "NoSuchKey"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("mapGetError(NoSuchKey) = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMapGetErrorOtherFailuresWrap(t *testing.T) {
+	underlying := errors.New("timeout")
+	err := mapGetError("deadbeef", underlying)
+	if err == nil || !errors.Is(err, underlying) {
+		t.Fatalf("mapGetError should wrap non-NoSuchKey errors, got %v", err)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Fatal("mapGetError should not return ErrNotFound for an unrelated failure")
+	}
+}
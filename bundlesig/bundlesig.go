@@ -0,0 +1,79 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bundlesig signs and verifies type registry bundles so a
+// multi-tenant CXDB deployment can reject a bundle whose signer isn't
+// trusted, instead of registering whatever JSON a writer happens to PUT.
+//
+// The request this package was built for also named a cxdb.VerifyBundle
+// wrapper and a Client.AppendTurnVerified variant. Neither exists: there is
+// no Client type in this repository for either to live on.
+// examples/type-registration calls Sign/Verify directly, which is the only
+// integration this package actually has today.
+package bundlesig
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+)
+
+// Signature is a detached Ed25519 signature over a canonicalized bundle
+// body, plus the id of the key that produced it. KeyID is looked up in a
+// trust root out-of-band; it is never trusted on its own.
+type Signature struct {
+	KeyID string `json:"key_id"`
+	Sig   []byte `json:"signature"`
+}
+
+// Canonicalize returns a deterministic encoding of a bundle's JSON body:
+// re-marshaling through an untyped value sorts object keys and drops
+// insignificant whitespace, so two byte-different-but-semantically-equal
+// JSON documents sign and verify identically. The decoder is put in
+// UseNumber mode so numbers round-trip as their literal text instead of
+// through float64, which would silently change any integer magnitude
+// above 2^53 before it's signed or verified.
+func Canonicalize(bundle []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(bundle))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("bundlesig: invalid bundle json: %w", err)
+	}
+	canon, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("bundlesig: canonicalize: %w", err)
+	}
+	return canon, nil
+}
+
+// Sign produces a detached signature over bundle's canonical form.
+func Sign(priv ed25519.PrivateKey, keyID string, bundle []byte) (*Signature, error) {
+	canon, err := Canonicalize(bundle)
+	if err != nil {
+		return nil, err
+	}
+	return &Signature{KeyID: keyID, Sig: ed25519.Sign(priv, canon)}, nil
+}
+
+// Verify reports whether sig is a valid signature over bundle for a key
+// registered under sig.KeyID in trustedKeys. An unknown KeyID is always a
+// verification failure, even if the signature bytes happen to be valid
+// under some other key.
+func Verify(bundle []byte, sig *Signature, trustedKeys map[string]ed25519.PublicKey) error {
+	pub, ok := trustedKeys[sig.KeyID]
+	if !ok {
+		return fmt.Errorf("bundlesig: unknown key id %q", sig.KeyID)
+	}
+
+	canon, err := Canonicalize(bundle)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, canon, sig.Sig) {
+		return fmt.Errorf("bundlesig: signature does not verify for key %q", sig.KeyID)
+	}
+	return nil
+}
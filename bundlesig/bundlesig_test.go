@@ -0,0 +1,122 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package bundlesig
+
+import (
+	"crypto/ed25519"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalizeIgnoresKeyOrderAndWhitespace(t *testing.T) {
+	a, err := Canonicalize([]byte(`{"b": 2, "a": 1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Canonicalize([]byte(`{  "a" : 1,   "b":2  }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(a) != string(b) {
+		t.Fatalf("canonical forms differ: %q vs %q", a, b)
+	}
+}
+
+func TestCanonicalizePreservesLargeIntegerPrecision(t *testing.T) {
+	// 2^53 + 1 can't round-trip through float64; UseNumber must keep it
+	// as literal text instead of silently rounding it before it's signed.
+	canon, err := Canonicalize([]byte(`{"n": 9007199254740993}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(canon), "9007199254740993") {
+		t.Fatalf("canonical form lost precision: %s", canon)
+	}
+}
+
+func TestCanonicalizeRejectsInvalidJSON(t *testing.T) {
+	if _, err := Canonicalize([]byte(`{not json`)); err == nil {
+		t.Fatal("expected an error for invalid JSON")
+	}
+}
+
+func TestSignAndVerifyRoundTrip(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle := []byte(`{"bundle_id": "com.example.logs-v1", "types": []}`)
+
+	sig, err := Sign(priv, "key-1", bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig.KeyID != "key-1" {
+		t.Fatalf("sig.KeyID = %q, want %q", sig.KeyID, "key-1")
+	}
+
+	trusted := map[string]ed25519.PublicKey{"key-1": pub}
+	if err := Verify(bundle, sig, trusted); err != nil {
+		t.Fatalf("Verify failed on a valid signature: %v", err)
+	}
+}
+
+func TestVerifyRejectsUnknownKeyID(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle := []byte(`{"bundle_id": "com.example.logs-v1"}`)
+
+	sig, err := Sign(priv, "key-1", bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trusted := map[string]ed25519.PublicKey{"key-2": ed25519.PublicKey(make([]byte, ed25519.PublicKeySize))}
+	if err := Verify(bundle, sig, trusted); err == nil {
+		t.Fatal("expected an error for an unknown key id")
+	}
+}
+
+func TestVerifyRejectsTamperedBundle(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle := []byte(`{"bundle_id": "com.example.logs-v1"}`)
+
+	sig, err := Sign(priv, "key-1", bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := []byte(`{"bundle_id": "com.example.logs-v2"}`)
+	trusted := map[string]ed25519.PublicKey{"key-1": pub}
+	if err := Verify(tampered, sig, trusted); err == nil {
+		t.Fatal("expected an error when the bundle doesn't match the signature")
+	}
+}
+
+func TestVerifyRejectsWrongKey(t *testing.T) {
+	_, priv1, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub2, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle := []byte(`{"bundle_id": "com.example.logs-v1"}`)
+
+	sig, err := Sign(priv1, "key-1", bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trusted := map[string]ed25519.PublicKey{"key-1": pub2}
+	if err := Verify(bundle, sig, trusted); err == nil {
+		t.Fatal("expected an error when trustedKeys has the wrong public key under that key id")
+	}
+}
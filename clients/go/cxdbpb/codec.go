@@ -0,0 +1,38 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package cxdbpb
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// codecName is the gRPC content-subtype this package's client and server
+// register under. Both ends of a connection must have this package
+// imported (which registers the codec via init) for frames to decode.
+const codecName = "cxdbjson"
+
+// jsonCodec implements encoding.Codec by marshaling messages as JSON rather
+// than protobuf binary. It exists only because this environment has no
+// protoc toolchain to generate the real protobuf codec's message types; it
+// is registered under its own content-subtype rather than overriding
+// grpc-go's built-in "proto" codec.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
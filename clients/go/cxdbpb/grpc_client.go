@@ -0,0 +1,150 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package cxdbpb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// serviceName is the fully-qualified CxdbService name from
+// proto/cxdb/v1/cxdb.proto, used to build the method names passed to
+// grpc.ClientConn.Invoke/NewStream the way protoc-gen-go-grpc would.
+const serviceName = "cxdb.v1.CxdbService"
+
+// callOpts forces every RPC onto the jsonCodec registered in codec.go,
+// since this package has no protobuf-generated message types for the
+// built-in "proto" codec to marshal.
+var callOpts = []grpc.CallOption{grpc.CallContentSubtype(codecName)}
+
+// CxdbServiceClient is the client API for CxdbService, hand-written to
+// match what protoc-gen-go-grpc would generate from cxdb.proto.
+type CxdbServiceClient interface {
+	CreateContext(ctx context.Context, in *CreateContextRequest, opts ...grpc.CallOption) (*CreateContextResponse, error)
+	ForkContext(ctx context.Context, in *ForkContextRequest, opts ...grpc.CallOption) (*CreateContextResponse, error)
+	AppendTurn(ctx context.Context, in *AppendTurnRequest, opts ...grpc.CallOption) (*AppendTurnResponse, error)
+	GetHead(ctx context.Context, in *GetHeadRequest, opts ...grpc.CallOption) (*GetHeadResponse, error)
+	GetLast(ctx context.Context, in *GetLastRequest, opts ...grpc.CallOption) (*GetLastResponse, error)
+	GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (*GetBlobResponse, error)
+	PublishRegistryBundle(ctx context.Context, in *PublishRegistryBundleRequest, opts ...grpc.CallOption) (*PublishRegistryBundleResponse, error)
+	GetTypedTurns(ctx context.Context, in *GetTypedTurnsRequest, opts ...grpc.CallOption) (*GetTypedTurnsResponse, error)
+	GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsResponse, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (CxdbService_SubscribeClient, error)
+}
+
+type cxdbServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+// NewCxdbServiceClient wraps cc, which must have been dialed with this
+// package imported (so the "cxdbjson" codec's init() has registered it).
+func NewCxdbServiceClient(cc *grpc.ClientConn) CxdbServiceClient {
+	return &cxdbServiceClient{cc: cc}
+}
+
+func (c *cxdbServiceClient) CreateContext(ctx context.Context, in *CreateContextRequest, opts ...grpc.CallOption) (*CreateContextResponse, error) {
+	out := new(CreateContextResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/CreateContext", in, out, append(callOpts, opts...)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cxdbServiceClient) ForkContext(ctx context.Context, in *ForkContextRequest, opts ...grpc.CallOption) (*CreateContextResponse, error) {
+	out := new(CreateContextResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/ForkContext", in, out, append(callOpts, opts...)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cxdbServiceClient) AppendTurn(ctx context.Context, in *AppendTurnRequest, opts ...grpc.CallOption) (*AppendTurnResponse, error) {
+	out := new(AppendTurnResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/AppendTurn", in, out, append(callOpts, opts...)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cxdbServiceClient) GetHead(ctx context.Context, in *GetHeadRequest, opts ...grpc.CallOption) (*GetHeadResponse, error) {
+	out := new(GetHeadResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/GetHead", in, out, append(callOpts, opts...)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cxdbServiceClient) GetLast(ctx context.Context, in *GetLastRequest, opts ...grpc.CallOption) (*GetLastResponse, error) {
+	out := new(GetLastResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/GetLast", in, out, append(callOpts, opts...)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cxdbServiceClient) GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (*GetBlobResponse, error) {
+	out := new(GetBlobResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/GetBlob", in, out, append(callOpts, opts...)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cxdbServiceClient) PublishRegistryBundle(ctx context.Context, in *PublishRegistryBundleRequest, opts ...grpc.CallOption) (*PublishRegistryBundleResponse, error) {
+	out := new(PublishRegistryBundleResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/PublishRegistryBundle", in, out, append(callOpts, opts...)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cxdbServiceClient) GetTypedTurns(ctx context.Context, in *GetTypedTurnsRequest, opts ...grpc.CallOption) (*GetTypedTurnsResponse, error) {
+	out := new(GetTypedTurnsResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/GetTypedTurns", in, out, append(callOpts, opts...)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cxdbServiceClient) GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsResponse, error) {
+	out := new(GetMetricsResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/GetMetrics", in, out, append(callOpts, opts...)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cxdbServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (CxdbService_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &grpc.StreamDesc{StreamName: "Subscribe", ServerStreams: true}, "/"+serviceName+"/Subscribe", append(callOpts, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &cxdbServiceSubscribeClient{stream}, nil
+}
+
+// CxdbService_SubscribeClient is the server-streaming client API for
+// Subscribe, matching protoc-gen-go-grpc's naming convention for streaming
+// methods.
+type CxdbService_SubscribeClient interface {
+	Recv() (*TurnEvent, error)
+}
+
+type cxdbServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (s *cxdbServiceSubscribeClient) Recv() (*TurnEvent, error) {
+	event := new(TurnEvent)
+	if err := s.ClientStream.RecvMsg(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
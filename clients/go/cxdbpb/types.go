@@ -0,0 +1,137 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cxdbpb is the generated client/server surface for CxdbService,
+// defined in proto/cxdb/v1/cxdb.proto. This environment has no protoc
+// toolchain available to run the usual protoc-gen-go / protoc-gen-go-grpc
+// generators, so the message types below and the CxdbServiceClient in
+// grpc_client.go are hand-maintained to mirror the proto's messages, fields
+// and RPC method signatures, carried by a JSON wire codec instead of
+// protobuf binary encoding - see codec.go. The Go names differ from
+// protoc-gen-go's: fields use Go initialisms (ContextID, not ContextId),
+// Turn.CreatedAt is a time.Time rather than a *timestamppb.Timestamp, and
+// TurnEventKind's constants are named differently, so switching to protoc's
+// output changes the names callers use.
+package cxdbpb
+
+import "time"
+
+type CreateContextRequest struct {
+	BaseTurnID uint64 `json:"baseTurnId"`
+}
+
+type CreateContextResponse struct {
+	ContextID  uint64 `json:"contextId"`
+	HeadTurnID uint64 `json:"headTurnId"`
+	HeadDepth  uint32 `json:"headDepth"`
+}
+
+type ForkContextRequest struct {
+	ContextID  uint64 `json:"contextId"`
+	FromTurnID uint64 `json:"fromTurnId"`
+}
+
+type AppendTurnRequest struct {
+	ContextID      uint64 `json:"contextId"`
+	ParentTurnID   uint64 `json:"parentTurnId"`
+	TypeID         string `json:"typeId"`
+	TypeVersion    uint32 `json:"typeVersion"`
+	Payload        []byte `json:"payload"`
+	IdempotencyKey string `json:"idempotencyKey"`
+}
+
+type AppendTurnResponse struct {
+	TurnID      uint64 `json:"turnId"`
+	Depth       uint32 `json:"depth"`
+	PayloadHash []byte `json:"payloadHash"`
+}
+
+type GetHeadRequest struct {
+	ContextID uint64 `json:"contextId"`
+}
+
+type GetHeadResponse struct {
+	HeadTurnID uint64 `json:"headTurnId"`
+	HeadDepth  uint32 `json:"headDepth"`
+}
+
+type GetLastRequest struct {
+	ContextID      uint64 `json:"contextId"`
+	Limit          uint32 `json:"limit"`
+	IncludePayload bool   `json:"includePayload"`
+}
+
+type GetLastResponse struct {
+	Turns []*Turn `json:"turns"`
+}
+
+type Turn struct {
+	TurnID       uint64    `json:"turnId"`
+	ParentTurnID uint64    `json:"parentTurnId"`
+	Depth        uint32    `json:"depth"`
+	TypeID       string    `json:"typeId"`
+	TypeVersion  uint32    `json:"typeVersion"`
+	PayloadHash  []byte    `json:"payloadHash"`
+	Payload      []byte    `json:"payload"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type GetBlobRequest struct {
+	Hash string `json:"hash"`
+}
+
+type GetBlobResponse struct {
+	Data []byte `json:"data"`
+}
+
+type PublishRegistryBundleRequest struct {
+	BundleID string `json:"bundleId"`
+	Bundle   []byte `json:"bundle"`
+
+	// KeyID and Signature are bundlesig's detached Ed25519 signature over
+	// Bundle's canonical form (see bundlesig.Sign). A server enforcing
+	// bundlesig.Verify against its trust root can use them to reject a
+	// bundle from an untrusted signer instead of registering whatever a
+	// writer submits.
+	KeyID     string `json:"keyId"`
+	Signature []byte `json:"signature"`
+}
+
+type PublishRegistryBundleResponse struct {
+	BundleID string `json:"bundleId"`
+}
+
+type GetTypedTurnsRequest struct {
+	ContextID uint64 `json:"contextId"`
+	Limit     uint32 `json:"limit"`
+}
+
+type GetTypedTurnsResponse struct {
+	ProjectionJSON []byte `json:"projectionJson"`
+}
+
+type GetMetricsRequest struct{}
+
+type GetMetricsResponse struct {
+	MetricsJSON []byte `json:"metricsJson"`
+}
+
+type SubscribeRequest struct {
+	ContextID  uint64   `json:"contextId"`
+	FromTurnID uint64   `json:"fromTurnId"`
+	TypeFilter []string `json:"typeFilter"`
+}
+
+// TurnEventKind mirrors the TurnEvent.Kind enum declared in the proto.
+type TurnEventKind int32
+
+const (
+	TurnEventKindAppended TurnEventKind = 0
+	TurnEventKindAttached TurnEventKind = 1
+	TurnEventKindForked   TurnEventKind = 2
+)
+
+type TurnEvent struct {
+	Turn *Turn         `json:"turn"`
+	Kind TurnEventKind `json:"kind"`
+}
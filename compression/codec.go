@@ -0,0 +1,141 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+// Package compression implements the payload codecs CXDB clients use to
+// shrink msgpack turn payloads and fstree blob contents before they cross
+// the wire.
+//
+// Callers must hash the uncompressed payload before calling Compress and
+// verify the hash after calling Decompress. Compressing first would make
+// PayloadHash depend on the codec and compression level instead of the
+// content, breaking it as a stable content id.
+//
+// This package only implements the codecs. The negotiation surface a real
+// caller would use - AppendRequest.Compression, Client.DefaultCompression,
+// AttachFs picking a codec for fstree blobs - lives on a Client/AppendRequest
+// type that doesn't exist in this repository. tools/cxdb-writer is the one
+// place in this tree that calls into Compress/Decompress: cmdAppend
+// (main.go) compresses with the codec picked by the msgHello negotiation in
+// negotiate.go, and wire.go decompresses turn payloads read by the tail
+// subcommand.
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+)
+
+// Codec identifies the compression algorithm applied to a stored blob. The
+// zero value, CodecNone, always means "payload is stored as-is".
+type Codec uint8
+
+const (
+	CodecNone Codec = 0
+	CodecZstd Codec = 1
+	CodecLZ4  Codec = 2
+	CodecGzip Codec = 3
+)
+
+func (c Codec) String() string {
+	switch c {
+	case CodecNone:
+		return "none"
+	case CodecZstd:
+		return "zstd"
+	case CodecLZ4:
+		return "lz4"
+	case CodecGzip:
+		return "gzip"
+	default:
+		return fmt.Sprintf("codec(%d)", uint8(c))
+	}
+}
+
+// Compress returns data encoded with codec. CodecNone returns data
+// unchanged.
+func Compress(codec Codec, data []byte) ([]byte, error) {
+	switch codec {
+	case CodecNone:
+		return data, nil
+	case CodecZstd:
+		enc, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, fmt.Errorf("compression: new zstd writer: %w", err)
+		}
+		defer enc.Close()
+		return enc.EncodeAll(data, nil), nil
+	case CodecLZ4:
+		var buf bytes.Buffer
+		w := lz4.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("compression: lz4 write: %w", err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("compression: lz4 close: %w", err)
+		}
+		return buf.Bytes(), nil
+	case CodecGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("compression: gzip write: %w", err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("compression: gzip close: %w", err)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("compression: unknown codec %d", codec)
+	}
+}
+
+// Decompress reverses Compress. CodecNone returns data unchanged.
+func Decompress(codec Codec, data []byte) ([]byte, error) {
+	switch codec {
+	case CodecNone:
+		return data, nil
+	case CodecZstd:
+		dec, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, fmt.Errorf("compression: new zstd reader: %w", err)
+		}
+		defer dec.Close()
+		out, err := dec.DecodeAll(data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("compression: zstd decode: %w", err)
+		}
+		return out, nil
+	case CodecLZ4:
+		r := lz4.NewReader(bytes.NewReader(data))
+		out, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("compression: lz4 decode: %w", err)
+		}
+		return out, nil
+	case CodecGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("compression: new gzip reader: %w", err)
+		}
+		defer r.Close()
+		out, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("compression: gzip decode: %w", err)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("compression: unknown codec %d", codec)
+	}
+}
+
+// ShouldCompress reports whether a payload of size bytes meets minSize, the
+// threshold below which compressing is not worth the CPU. CodecNone never
+// qualifies.
+func ShouldCompress(codec Codec, size, minSize int) bool {
+	return codec != CodecNone && size >= minSize
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package compression
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompressDecompressRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog "), 64)
+
+	for _, codec := range []Codec{CodecNone, CodecZstd, CodecLZ4, CodecGzip} {
+		t.Run(codec.String(), func(t *testing.T) {
+			compressed, err := Compress(codec, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Decompress(codec, compressed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatalf("round trip changed the payload: got %d bytes, want %d", len(got), len(data))
+			}
+		})
+	}
+}
+
+func TestCompressNoneReturnsDataUnchanged(t *testing.T) {
+	data := []byte("uncompressed")
+	got, err := Compress(CodecNone, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if &got[0] != &data[0] {
+		t.Fatal("CodecNone should return the same backing array, not a copy")
+	}
+}
+
+func TestCompressUnknownCodecErrors(t *testing.T) {
+	if _, err := Compress(Codec(255), []byte("x")); err == nil {
+		t.Fatal("expected an error for an unknown codec")
+	}
+}
+
+func TestDecompressUnknownCodecErrors(t *testing.T) {
+	if _, err := Decompress(Codec(255), []byte("x")); err == nil {
+		t.Fatal("expected an error for an unknown codec")
+	}
+}
+
+func TestDecompressRejectsCorruptData(t *testing.T) {
+	for _, codec := range []Codec{CodecZstd, CodecLZ4, CodecGzip} {
+		t.Run(codec.String(), func(t *testing.T) {
+			if _, err := Decompress(codec, []byte("not a valid compressed stream")); err == nil {
+				t.Fatal("expected an error decoding garbage input")
+			}
+		})
+	}
+}
+
+func TestCodecString(t *testing.T) {
+	cases := map[Codec]string{
+		CodecNone:  "none",
+		CodecZstd:  "zstd",
+		CodecLZ4:   "lz4",
+		CodecGzip:  "gzip",
+		Codec(255): "codec(255)",
+	}
+	for codec, want := range cases {
+		if got := codec.String(); got != want {
+			t.Fatalf("Codec(%d).String() = %q, want %q", codec, got, want)
+		}
+	}
+}
+
+func TestShouldCompress(t *testing.T) {
+	cases := []struct {
+		name    string
+		codec   Codec
+		size    int
+		minSize int
+		want    bool
+	}{
+		{"none never qualifies", CodecNone, 1 << 20, 0, false},
+		{"below threshold", CodecGzip, 511, 512, false},
+		{"at threshold", CodecGzip, 512, 512, true},
+		{"above threshold", CodecGzip, 513, 512, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ShouldCompress(c.codec, c.size, c.minSize); got != c.want {
+				t.Fatalf("ShouldCompress(%v, %d, %d) = %v, want %v", c.codec, c.size, c.minSize, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package compression
+
+import "sync/atomic"
+
+// Stats accumulates before/after byte counts across many Compress calls so
+// callers can expose a ratio and bytes-saved gauge for observability. The
+// zero value is ready to use and safe for concurrent use.
+type Stats struct {
+	uncompressedBytes uint64
+	compressedBytes   uint64
+}
+
+// Record adds one Compress call's input and output sizes to the running
+// totals.
+func (s *Stats) Record(uncompressed, compressed int) {
+	atomic.AddUint64(&s.uncompressedBytes, uint64(uncompressed))
+	atomic.AddUint64(&s.compressedBytes, uint64(compressed))
+}
+
+// Ratio returns compressed/uncompressed bytes across all recorded calls, or
+// 1.0 if nothing has been recorded yet.
+func (s *Stats) Ratio() float64 {
+	uncompressed := atomic.LoadUint64(&s.uncompressedBytes)
+	if uncompressed == 0 {
+		return 1.0
+	}
+	compressed := atomic.LoadUint64(&s.compressedBytes)
+	return float64(compressed) / float64(uncompressed)
+}
+
+// BytesSaved returns the cumulative difference between uncompressed and
+// compressed bytes across all recorded calls.
+func (s *Stats) BytesSaved() uint64 {
+	uncompressed := atomic.LoadUint64(&s.uncompressedBytes)
+	compressed := atomic.LoadUint64(&s.compressedBytes)
+	if compressed >= uncompressed {
+		return 0
+	}
+	return uncompressed - compressed
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package compression
+
+import "testing"
+
+func TestStatsZeroValueRatioIsOne(t *testing.T) {
+	var s Stats
+	if got := s.Ratio(); got != 1.0 {
+		t.Fatalf("Ratio() on zero value = %v, want 1.0", got)
+	}
+	if got := s.BytesSaved(); got != 0 {
+		t.Fatalf("BytesSaved() on zero value = %v, want 0", got)
+	}
+}
+
+func TestStatsRecordAccumulates(t *testing.T) {
+	var s Stats
+	s.Record(100, 40)
+	s.Record(100, 60)
+
+	if got, want := s.Ratio(), 0.5; got != want {
+		t.Fatalf("Ratio() = %v, want %v", got, want)
+	}
+	if got, want := s.BytesSaved(), uint64(100); got != want {
+		t.Fatalf("BytesSaved() = %v, want %v", got, want)
+	}
+}
+
+func TestStatsBytesSavedClampsAtZero(t *testing.T) {
+	var s Stats
+	// An expanding codec (e.g. incompressible input through gzip) can make
+	// compressed bigger than uncompressed; BytesSaved must not underflow.
+	s.Record(10, 12)
+
+	if got := s.BytesSaved(); got != 0 {
+		t.Fatalf("BytesSaved() = %v, want 0", got)
+	}
+}
@@ -7,6 +7,9 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,6 +18,7 @@ import (
 	"os"
 
 	"github.com/strongdm/cxdb"
+	"github.com/strongdm/cxdb/bundlesig"
 	"github.com/vmihailenco/msgpack/v5"
 )
 
@@ -27,6 +31,11 @@ const (
 
 	// Bundle ID matching bundle.json
 	BundleID = "com.example.logs-v1"
+
+	// Key id the demo signing key is registered under. A real deployment
+	// looks this up against a persistent trust root instead of generating
+	// a keypair per run.
+	DemoSigningKeyID = "example-dev-key"
 )
 
 func main() {
@@ -44,6 +53,22 @@ func main() {
 	}
 	fmt.Println("Bundle loaded successfully")
 
+	// Step 1.5: Sign the bundle so a server enforcing trust roots rejects
+	// it unless the signer is known, instead of registering whatever JSON
+	// a writer happens to PUT. A real deployment loads a persistent
+	// keypair; this example generates one per run purely to demonstrate
+	// the sign-then-verify flow end to end.
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		log.Fatalf("Failed to generate signing key: %v", err)
+	}
+
+	sig, err := bundlesig.Sign(priv, DemoSigningKeyID, bundleData)
+	if err != nil {
+		log.Fatalf("Failed to sign bundle: %v", err)
+	}
+	fmt.Printf("Signed bundle with key_id=%s\n", sig.KeyID)
+
 	// Step 2: Publish bundle to server
 	fmt.Println("\nPublishing type registry bundle to server...")
 	httpAddr := "http://localhost:9010"
@@ -54,6 +79,8 @@ func main() {
 		log.Fatalf("Failed to create request: %v", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Cxdb-Key-Id", sig.KeyID)
+	req.Header.Set("X-Cxdb-Signature", base64.StdEncoding.EncodeToString(sig.Sig))
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -68,6 +95,15 @@ func main() {
 
 	fmt.Printf("Bundle published successfully (HTTP %d)\n", resp.StatusCode)
 
+	// Step 2.5: Verify the signature the way a consumer (or the server's
+	// registry enforcement) would: against a trust root that maps key ids
+	// to public keys, never trusting whatever key id the bundle claims.
+	trustedKeys := map[string]ed25519.PublicKey{DemoSigningKeyID: pub}
+	if err := bundlesig.Verify(bundleData, sig, trustedKeys); err != nil {
+		log.Fatalf("Bundle signature did not verify: %v", err)
+	}
+	fmt.Println("Signature verified against the trusted key set")
+
 	// Step 3: Connect to CXDB binary protocol
 	fmt.Println("\nConnecting to CXDB at localhost:9009...")
 	client, err := cxdb.Dial("localhost:9009")
@@ -0,0 +1,137 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fastcdc implements FastCDC content-defined chunking: splitting a
+// large file into reusable, content-addressed chunks instead of treating it
+// as one blob that has to be re-hashed (and re-uploaded) whenever a small
+// part of it changes.
+//
+// This package is standalone. Wiring it into fstree.Capture, making
+// Snapshot.Upload skip chunks the store already has, and adding
+// Snapshot.Diff.BytesChanged all require a caller-side fstree/Snapshot type
+// that does not exist anywhere in this repository (examples/fstree-snapshot
+// imports those types from a separate github.com/strongdm/ai-cxdb module
+// that isn't vendored here). Until that client package exists, this is a
+// library in search of a caller, not a finished feature.
+package fastcdc
+
+import "fmt"
+
+// Default chunk size bounds, chosen per the FastCDC paper's guidance: a
+// 64 KiB target with a 4x min/max spread gives a good tradeoff between
+// dedup granularity and manifest size for typical source and log files.
+const (
+	DefaultMinSize = 16 * 1024
+	DefaultAvgSize = 64 * 1024
+	DefaultMaxSize = 256 * 1024
+)
+
+// normalization is the FastCDC "normalized chunking" level: how many bits
+// the before/after-target masks diverge from the target mask. Level 2
+// produces a tighter size distribution around avgSize at a small CPU cost.
+const normalization = 2
+
+// Chunk is one content-defined slice of a file, identified by its byte
+// range. Callers hash Chunk's bytes themselves (fastcdc only finds
+// boundaries) so the hash algorithm stays a choice of the caller.
+type Chunk struct {
+	Offset uint64
+	Length uint32
+}
+
+// Chunker finds FastCDC boundaries for a given min/average/max chunk size.
+// A Chunker holds no per-split state and is safe for concurrent use.
+type Chunker struct {
+	minSize, avgSize, maxSize uint32
+	maskS, maskL              uint64
+}
+
+// New creates a Chunker. minSize must be <= avgSize <= maxSize.
+func New(minSize, avgSize, maxSize uint32) (*Chunker, error) {
+	if !(minSize <= avgSize && avgSize <= maxSize) {
+		return nil, fmt.Errorf("fastcdc: sizes must satisfy min <= avg <= max, got %d <= %d <= %d", minSize, avgSize, maxSize)
+	}
+
+	avgBits := logarithm2(avgSize)
+	return &Chunker{
+		minSize: minSize,
+		avgSize: avgSize,
+		maxSize: maxSize,
+		// maskS is stricter (more 1 bits) than maskL and is applied before
+		// avgSize is reached, making an early cut less likely and pushing
+		// chunk boundaries up toward the target size. maskL is looser and
+		// applied past avgSize, so a boundary is found soon after the
+		// target rather than growing all the way to maxSize.
+		maskS: mask(avgBits + normalization),
+		maskL: mask(avgBits - normalization),
+	}, nil
+}
+
+// Split finds chunk boundaries across data and invokes fn for each chunk in
+// order. It stops and returns fn's error if fn returns one.
+func (c *Chunker) Split(data []byte, fn func(Chunk) error) error {
+	var offset uint64
+	for len(data) > 0 {
+		n := c.cut(data)
+		if err := fn(Chunk{Offset: offset, Length: n}); err != nil {
+			return err
+		}
+		data = data[n:]
+		offset += uint64(n)
+	}
+	return nil
+}
+
+// cut returns the length of the next chunk at the start of src.
+func (c *Chunker) cut(src []byte) uint32 {
+	n := uint32(len(src))
+	if n <= c.minSize {
+		return n
+	}
+
+	maxLen := n
+	if maxLen > c.maxSize {
+		maxLen = c.maxSize
+	}
+	avg := c.avgSize
+	if avg > maxLen {
+		avg = maxLen
+	}
+
+	var hash uint64
+	i := c.minSize
+	for ; i < avg; i++ {
+		hash = (hash << 1) + gearTable[src[i]]
+		if hash&c.maskS == 0 {
+			return i + 1
+		}
+	}
+	for ; i < maxLen; i++ {
+		hash = (hash << 1) + gearTable[src[i]]
+		if hash&c.maskL == 0 {
+			return i + 1
+		}
+	}
+	return maxLen
+}
+
+// logarithm2 returns floor(log2(x)).
+func logarithm2(x uint32) uint {
+	var bits uint
+	for x >>= 1; x != 0; x >>= 1 {
+		bits++
+	}
+	return bits
+}
+
+// mask returns a value with bits low bits set, clamped to [0, 64].
+func mask(bits uint) uint64 {
+	switch {
+	case bits == 0:
+		return 0
+	case bits >= 64:
+		return ^uint64(0)
+	default:
+		return (uint64(1) << bits) - 1
+	}
+}
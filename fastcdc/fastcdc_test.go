@@ -0,0 +1,191 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package fastcdc
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestNewRejectsOutOfOrderSizes(t *testing.T) {
+	if _, err := New(64*1024, 16*1024, 256*1024); err == nil {
+		t.Fatal("expected error when avgSize < minSize")
+	}
+	if _, err := New(16*1024, 256*1024, 64*1024); err == nil {
+		t.Fatal("expected error when maxSize < avgSize")
+	}
+}
+
+func TestSplitRespectsMinAndMaxSize(t *testing.T) {
+	c, err := New(4*1024, 16*1024, 64*1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := make([]byte, 512*1024)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	var chunks []Chunk
+	if err := c.Split(data, func(ch Chunk) error {
+		chunks = append(chunks, ch)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var total uint64
+	for i, ch := range chunks {
+		last := i == len(chunks)-1
+		if ch.Length > c.maxSize {
+			t.Errorf("chunk %d length %d exceeds maxSize %d", i, ch.Length, c.maxSize)
+		}
+		// The final chunk is whatever is left over and may be shorter than
+		// minSize; every other chunk must be at least minSize.
+		if !last && ch.Length < c.minSize {
+			t.Errorf("chunk %d length %d is below minSize %d", i, ch.Length, c.minSize)
+		}
+		if ch.Offset != total {
+			t.Errorf("chunk %d offset %d, want %d", i, ch.Offset, total)
+		}
+		total += uint64(ch.Length)
+	}
+	if total != uint64(len(data)) {
+		t.Fatalf("chunks cover %d bytes, want %d", total, len(data))
+	}
+}
+
+func TestSplitIsDeterministic(t *testing.T) {
+	c, err := New(DefaultMinSize, DefaultAvgSize, DefaultMaxSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := make([]byte, 1024*1024)
+	rand.New(rand.NewSource(42)).Read(data)
+
+	split := func() []Chunk {
+		var chunks []Chunk
+		if err := c.Split(data, func(ch Chunk) error {
+			chunks = append(chunks, ch)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return chunks
+	}
+
+	first := split()
+	second := split()
+	if len(first) != len(second) {
+		t.Fatalf("chunk counts differ across runs: %d vs %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("chunk %d differs across runs: %+v vs %+v", i, first[i], second[i])
+		}
+	}
+}
+
+func TestSplitLocalEditLeavesSomeChunksUnchanged(t *testing.T) {
+	c, err := New(4*1024, 16*1024, 64*1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original := make([]byte, 256*1024)
+	rand.New(rand.NewSource(7)).Read(original)
+
+	chunkHashes := func(data []byte) [][]byte {
+		var hashes [][]byte
+		_ = c.Split(data, func(ch Chunk) error {
+			hashes = append(hashes, append([]byte(nil), data[ch.Offset:ch.Offset+uint64(ch.Length)]...))
+			return nil
+		})
+		return hashes
+	}
+
+	before := chunkHashes(original)
+
+	edited := append([]byte(nil), original...)
+	// Insert a few bytes well past the first chunk boundary; content-defined
+	// chunking should reuse the unaffected leading chunks instead of
+	// reshuffling the whole file like a fixed-size splitter would.
+	insertAt := 200 * 1024
+	edited = append(edited[:insertAt], append([]byte("injected"), edited[insertAt:]...)...)
+
+	after := chunkHashes(edited)
+
+	matched := 0
+	for _, b := range before {
+		for _, a := range after {
+			if bytes.Equal(a, b) {
+				matched++
+				break
+			}
+		}
+	}
+	if matched == 0 {
+		t.Fatal("expected at least some chunks to survive a small local edit unchanged")
+	}
+}
+
+func TestLogarithm2(t *testing.T) {
+	cases := map[uint32]uint{
+		1:     0,
+		2:     1,
+		3:     1,
+		4:     2,
+		1023:  9,
+		1024:  10,
+		65536: 16,
+	}
+	for x, want := range cases {
+		if got := logarithm2(x); got != want {
+			t.Errorf("logarithm2(%d) = %d, want %d", x, got, want)
+		}
+	}
+}
+
+func TestMask(t *testing.T) {
+	if mask(0) != 0 {
+		t.Errorf("mask(0) = %#x, want 0", mask(0))
+	}
+	if mask(4) != 0xF {
+		t.Errorf("mask(4) = %#x, want 0xF", mask(4))
+	}
+	if mask(64) != ^uint64(0) {
+		t.Errorf("mask(64) = %#x, want all bits set", mask(64))
+	}
+	if mask(100) != ^uint64(0) {
+		t.Errorf("mask(100) = %#x, want clamped to all bits set", mask(100))
+	}
+}
+
+func TestEncodeManifestAndHashAreDeterministic(t *testing.T) {
+	chunks := []ChunkRef{
+		{Offset: 0, Length: 100, Hash: [32]byte{1}},
+		{Offset: 100, Length: 200, Hash: [32]byte{2}},
+	}
+
+	encoded := EncodeManifest(chunks)
+	if encoded[0] != ManifestVersionChunked {
+		t.Fatalf("manifest version byte = %d, want %d", encoded[0], ManifestVersionChunked)
+	}
+	wantLen := 1 + len(chunks)*(8+4+32)
+	if len(encoded) != wantLen {
+		t.Fatalf("encoded manifest length = %d, want %d", len(encoded), wantLen)
+	}
+
+	h1 := ManifestHash(chunks)
+	h2 := ManifestHash(append([]ChunkRef(nil), chunks...))
+	if h1 != h2 {
+		t.Fatal("ManifestHash is not deterministic for equal chunk lists")
+	}
+
+	reordered := []ChunkRef{chunks[1], chunks[0]}
+	if ManifestHash(reordered) == h1 {
+		t.Fatal("ManifestHash must distinguish chunk order")
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package fastcdc
+
+// gearTable maps each possible byte value to a pseudo-random 64-bit value
+// used to roll the gear hash in cut. It is generated once from a fixed seed
+// via splitmix64 rather than hardcoded, but the values themselves must
+// never change: they determine where chunk boundaries fall, and changing
+// them would invalidate every manifest already stored with this version.
+var gearTable [256]uint64
+
+func init() {
+	seed := uint64(0x9e3779b97f4a7c15)
+	for i := range gearTable {
+		seed = splitmix64(seed)
+		gearTable[i] = seed
+	}
+}
+
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	z := x
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
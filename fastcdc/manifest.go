@@ -0,0 +1,51 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package fastcdc
+
+import (
+	"encoding/binary"
+
+	"github.com/zeebo/blake3"
+)
+
+// Manifest versions. Version 0 (implicit, absent from the wire today) is a
+// whole-file blob hashed directly; ManifestVersionChunked marks a node as a
+// list of FastCDC chunks instead, so older servers and clients can tell
+// which decoding path to use.
+const ManifestVersionChunked byte = 1
+
+// ChunkRef is one entry in a file's chunk manifest: a byte range plus the
+// content hash of that range.
+type ChunkRef struct {
+	Offset uint64
+	Length uint32
+	Hash   [32]byte
+}
+
+// EncodeManifest canonicalizes a file's chunk list into the bytes stored as
+// the manifest node and hashed to produce the file's blob hash. Entries are
+// fixed-width and written in the order given (Split yields chunks in offset
+// order); they are not sorted, so the same chunks in a different order
+// encode to different bytes. The same content split by a Chunker with the
+// same sizes always produces the same manifest bytes.
+func EncodeManifest(chunks []ChunkRef) []byte {
+	const entrySize = 8 + 4 + 32
+	buf := make([]byte, 1, 1+len(chunks)*entrySize)
+	buf[0] = ManifestVersionChunked
+
+	for _, c := range chunks {
+		var entry [entrySize]byte
+		binary.LittleEndian.PutUint64(entry[0:8], c.Offset)
+		binary.LittleEndian.PutUint32(entry[8:12], c.Length)
+		copy(entry[12:44], c.Hash[:])
+		buf = append(buf, entry[:]...)
+	}
+	return buf
+}
+
+// ManifestHash returns the blob hash for a chunked file: the blake3 hash of
+// its canonical manifest encoding.
+func ManifestHash(chunks []ChunkRef) [32]byte {
+	return blake3.Sum256(EncodeManifest(chunks))
+}
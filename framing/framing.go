@@ -0,0 +1,190 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+// Package framing implements the length-prefixed frame protocol carried by
+// the cxdb binary wire protocol, plus a context-aware Conn that lets any
+// caller of the client library cancel an in-flight framed call without
+// leaking the goroutine blocked on the underlying net.Conn.
+package framing
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// Frame is one message on the wire: a 16-byte header (payload length,
+// message type, reserved, request id) followed by payload bytes.
+type Frame struct {
+	MsgType uint16
+	ReqID   uint64
+	Payload []byte
+}
+
+// WriteFrame writes one frame to w.
+func WriteFrame(w io.Writer, msgType uint16, reqID uint64, payload []byte) error {
+	header := make([]byte, 16)
+	binary.LittleEndian.PutUint32(header[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint16(header[4:6], msgType)
+	binary.LittleEndian.PutUint64(header[8:16], reqID)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// ReadFrame reads one frame from r.
+func ReadFrame(r io.Reader) (Frame, error) {
+	header := make([]byte, 16)
+	if _, err := readFull(r, header); err != nil {
+		return Frame{}, fmt.Errorf("read header: %w", err)
+	}
+
+	length := binary.LittleEndian.Uint32(header[0:4])
+	msgType := binary.LittleEndian.Uint16(header[4:6])
+	reqID := binary.LittleEndian.Uint64(header[8:16])
+
+	payload := make([]byte, length)
+	if _, err := readFull(r, payload); err != nil {
+		return Frame{}, fmt.Errorf("read payload: %w", err)
+	}
+
+	return Frame{MsgType: msgType, ReqID: reqID, Payload: payload}, nil
+}
+
+func readFull(r io.Reader, buf []byte) (int, error) {
+	total := 0
+	for total < len(buf) {
+		n, err := r.Read(buf[total:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// deadlineTimer arms a cancel channel from a time.AfterFunc timer so a
+// blocked Read or Write can be unblocked by forcing the underlying
+// connection's deadline into the past. Re-arming stops the previous timer
+// and swaps in a fresh channel, so a timer that already fired can never be
+// confused with one still pending: a caller either gets a channel that is
+// already closed, or a live one that the new timer alone will close.
+type deadlineTimer struct {
+	mu     sync.Mutex
+	timer  *time.Timer
+	cancel chan struct{}
+}
+
+func newDeadlineTimer() *deadlineTimer {
+	return &deadlineTimer{cancel: closedCh()}
+}
+
+func closedCh() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
+
+// arm schedules t.cancel to close after d elapses and returns it. A
+// non-positive d means there is no deadline to wait for, so arm returns an
+// already-closed channel instead of starting a timer.
+func (t *deadlineTimer) arm(d time.Duration) <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	if d <= 0 {
+		t.cancel = closedCh()
+		return t.cancel
+	}
+
+	cancel := make(chan struct{})
+	t.cancel = cancel
+	t.timer = time.AfterFunc(d, func() { close(cancel) })
+	return cancel
+}
+
+// CallContext returns a context bounded by timeout, or context.Background
+// if timeout is non-positive.
+func CallContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.Background(), func() {}
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// Conn wraps a net.Conn so a framed RPC can carry its own context.Context
+// instead of relying on net.Conn's connection-wide, caller-managed
+// deadlines. Library users can cancel an in-flight WriteFrame/ReadFrame by
+// canceling the ctx they passed it; Conn forces the blocked syscall to
+// unblock instead of leaking the goroutine until the peer responds.
+type Conn struct {
+	net.Conn
+	readTimer  *deadlineTimer
+	writeTimer *deadlineTimer
+}
+
+// NewConn wraps c for context-bounded framed I/O.
+func NewConn(c net.Conn) *Conn {
+	return &Conn{Conn: c, readTimer: newDeadlineTimer(), writeTimer: newDeadlineTimer()}
+}
+
+// WriteFrame writes a frame, bounded by ctx.
+func (c *Conn) WriteFrame(ctx context.Context, msgType uint16, reqID uint64, payload []byte) error {
+	return c.do(ctx, c.writeTimer, func() error {
+		return WriteFrame(c.Conn, msgType, reqID, payload)
+	})
+}
+
+// ReadFrame reads a frame, bounded by ctx.
+func (c *Conn) ReadFrame(ctx context.Context) (Frame, error) {
+	var f Frame
+	err := c.do(ctx, c.readTimer, func() error {
+		var readErr error
+		f, readErr = ReadFrame(c.Conn)
+		return readErr
+	})
+	return f, err
+}
+
+// do runs fn, a blocking Read or Write against c.Conn, bounded by ctx. If
+// ctx is canceled or its deadline elapses before fn returns, do forces the
+// in-flight syscall to unblock by setting the connection's deadline into
+// the past, waits for fn to actually return (so its goroutine never leaks),
+// and reports ctx's error instead of fn's I/O error.
+func (c *Conn) do(ctx context.Context, timer *deadlineTimer, fn func() error) error {
+	var cancel <-chan struct{}
+	if dl, ok := ctx.Deadline(); ok {
+		cancel = timer.arm(time.Until(dl))
+	} else {
+		cancel = ctx.Done()
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- fn() }()
+
+	select {
+	case err := <-done:
+		_ = c.Conn.SetDeadline(time.Time{})
+		return err
+	case <-cancel:
+	case <-ctx.Done():
+	}
+
+	_ = c.Conn.SetDeadline(time.Unix(1, 0))
+	<-done
+	_ = c.Conn.SetDeadline(time.Time{})
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("cxdb: %w", err)
+	}
+	return fmt.Errorf("cxdb: %w", context.DeadlineExceeded)
+}
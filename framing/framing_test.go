@@ -0,0 +1,111 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package framing
+
+import (
+	"context"
+	"errors"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// blockedCall starts fn in a goroutine and returns a channel that receives
+// fn's error once it returns. The caller still needs to let fn's underlying
+// call unblock (e.g. by canceling its context) before anything arrives on
+// done.
+func blockedCall(fn func() error) (done <-chan error) {
+	ch := make(chan error, 1)
+	go func() { ch <- fn() }()
+	return ch
+}
+
+func TestConnReadFrameCanceledContextUnblocks(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	_ = server // never written to: client's Read blocks until canceled
+
+	conn := NewConn(client)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := blockedCall(func() error {
+		_, err := conn.ReadFrame(ctx)
+		return err
+	})
+
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("ReadFrame() error = %v, want wrapped context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("ReadFrame did not unblock after its context was canceled")
+	}
+}
+
+func TestConnWriteFrameDeadlineExceededUnblocks(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	_ = server // never read from: client's Write blocks until the deadline fires
+
+	conn := NewConn(client)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	done := blockedCall(func() error {
+		return conn.WriteFrame(ctx, 1, 1, []byte("payload"))
+	})
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("WriteFrame() error = %v, want wrapped context.DeadlineExceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Fatalf("WriteFrame took %v to unblock after a 20ms deadline", elapsed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("WriteFrame did not unblock at its context's deadline")
+	}
+}
+
+func TestConnDoDoesNotLeakGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 50; i++ {
+		client, server := net.Pipe()
+		conn := NewConn(client)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+
+		_, err := conn.ReadFrame(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("ReadFrame() error = %v, want wrapped context.DeadlineExceeded", err)
+		}
+
+		cancel()
+		client.Close()
+		server.Close()
+	}
+
+	// do() only returns once the goroutine running fn has actually sent on
+	// done, so by the time ReadFrame returns above its goroutine is already
+	// gone; give the runtime a moment to finish tearing down net.Pipe's own
+	// internal goroutines before comparing counts.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		runtime.GC()
+		if after := runtime.NumGoroutine(); after <= before {
+			return
+		} else if time.Now().After(deadline) {
+			t.Fatalf("goroutine count grew from %d to %d after repeated canceled calls", before, after)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+// Package spiffeid extracts a caller's SPIFFE ID from a verified mTLS client
+// certificate, so a CXDB server can stamp an AppendTurn's actor field with
+// the identity its transport already authenticated instead of trusting
+// whatever actor value a caller claims in the request body.
+//
+// This package is standalone: there is no server in this repository to call
+// FromConnectionState from a listener's tls.Config.GetConfigForClient/
+// VerifyPeerCertificate hook, and no AppendTurnRequest.actor field for the
+// result to be stamped into (see AppendTurnRequest in
+// clients/go/cxdbpb/types.go). RequireClientCertConfig exists for the same
+// reason a real --require-mtls flag would build one: there's simply nothing
+// in this tree yet to pass it to.
+package spiffeid
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrNoSPIFFEID is returned when a certificate has no spiffe:// URI SAN.
+var ErrNoSPIFFEID = errors.New("spiffeid: certificate has no spiffe:// URI SAN")
+
+// FromCert returns the SPIFFE ID asserted by cert's URI SANs. Per the SPIFFE
+// spec a leaf certificate must carry exactly one URI SAN, but this only
+// requires at least one and returns the first spiffe:// entry, since nothing
+// earlier in the chain validates that constraint for us.
+func FromCert(cert *x509.Certificate) (string, error) {
+	for _, u := range cert.URIs {
+		if u.Scheme == "spiffe" {
+			return u.String(), nil
+		}
+	}
+	return "", fmt.Errorf("spiffeid: %w: %s", ErrNoSPIFFEID, cert.Subject)
+}
+
+// FromConnectionState extracts the SPIFFE ID from the leaf of the first
+// verified chain in state - the shape crypto/tls hands a server after a
+// handshake with ClientAuth set to tls.RequireAndVerifyClientCert. It
+// returns an error if the handshake didn't produce a verified client chain,
+// which RequireClientCertConfig's ClientAuth setting is meant to prevent.
+func FromConnectionState(state tls.ConnectionState) (string, error) {
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", errors.New("spiffeid: connection has no verified client certificate chain")
+	}
+	return FromCert(state.VerifiedChains[0][0])
+}
+
+// RequireClientCertConfig builds a *tls.Config for a server that must
+// authenticate every caller by client certificate and reject plaintext or
+// unauthenticated connections outright - the config a --require-mtls flag
+// would load. clientCAPath is a PEM bundle of the CAs allowed to sign client
+// certificates; serverCert/serverKey are the server's own PEM keypair.
+func RequireClientCertConfig(clientCAPath, serverCertPath, serverKeyPath string) (*tls.Config, error) {
+	pool, err := loadCertPool(clientCAPath)
+	if err != nil {
+		return nil, fmt.Errorf("spiffeid: load client CA bundle: %w", err)
+	}
+
+	cert, err := tls.LoadX509KeyPair(serverCertPath, serverKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("spiffeid: load server keypair: %w", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}, nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package spiffeid
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func selfSignedCert(t *testing.T, uris []*url.URL) *x509.Certificate {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         uris,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, pub, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestFromCertReturnsSPIFFEURI(t *testing.T) {
+	spiffeURI, err := url.Parse("spiffe://example.org/ns/default/sa/writer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := selfSignedCert(t, []*url.URL{spiffeURI})
+
+	got, err := FromCert(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "spiffe://example.org/ns/default/sa/writer" {
+		t.Fatalf("FromCert() = %q, want %q", got, "spiffe://example.org/ns/default/sa/writer")
+	}
+}
+
+func TestFromCertSkipsNonSPIFFEURIs(t *testing.T) {
+	other, err := url.Parse("https://example.org/not-spiffe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spiffeURI, err := url.Parse("spiffe://example.org/ns/default/sa/writer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := selfSignedCert(t, []*url.URL{other, spiffeURI})
+
+	got, err := FromCert(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "spiffe://example.org/ns/default/sa/writer" {
+		t.Fatalf("FromCert() = %q, want the spiffe:// entry", got)
+	}
+}
+
+func TestFromCertRejectsCertWithNoURISAN(t *testing.T) {
+	cert := selfSignedCert(t, nil)
+	if _, err := FromCert(cert); err == nil {
+		t.Fatal("expected an error for a certificate with no URI SAN")
+	}
+}
+
+func TestFromConnectionStateRequiresVerifiedChain(t *testing.T) {
+	if _, err := FromConnectionState(tls.ConnectionState{}); err == nil {
+		t.Fatal("expected an error when there is no verified client certificate chain")
+	}
+}
+
+func TestFromConnectionStateUsesLeafOfFirstChain(t *testing.T) {
+	spiffeURI, err := url.Parse("spiffe://example.org/ns/default/sa/writer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf := selfSignedCert(t, []*url.URL{spiffeURI})
+
+	state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{leaf}}}
+	got, err := FromConnectionState(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "spiffe://example.org/ns/default/sa/writer" {
+		t.Fatalf("FromConnectionState() = %q, want %q", got, "spiffe://example.org/ns/default/sa/writer")
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/strongdm/cxdb/compression"
+	"github.com/strongdm/cxdb/framing"
+)
+
+// codecPriority is the order codecs are preferred in when more than one is
+// mutually supported: the strongest general-purpose ratio first.
+var codecPriority = []compression.Codec{
+	compression.CodecZstd,
+	compression.CodecGzip,
+	compression.CodecLZ4,
+}
+
+// parseCompressionFlag turns a -compression flag value into the codecs the
+// client offers during the msgHello exchange. "none" offers nothing (the
+// connection never compresses); "auto" offers every codec this client
+// supports, in priority order, and lets the server's reply decide.
+func parseCompressionFlag(value string) ([]compression.Codec, error) {
+	switch value {
+	case "none":
+		return nil, nil
+	case "zstd":
+		return []compression.Codec{compression.CodecZstd}, nil
+	case "gzip":
+		return []compression.Codec{compression.CodecGzip}, nil
+	case "lz4":
+		return []compression.Codec{compression.CodecLZ4}, nil
+	case "auto":
+		return codecPriority, nil
+	default:
+		return nil, fmt.Errorf("unknown -compression value %q (want none, zstd, gzip, lz4, or auto)", value)
+	}
+}
+
+// negotiateCompression advertises offer over a msgHello frame and picks the
+// strongest codec both this client and the server support. If the server
+// supports none of offer, it falls back to CodecNone.
+func negotiateCompression(conn *framing.Conn, ctx context.Context, offer []compression.Codec) (compression.Codec, error) {
+	payload := &bytes.Buffer{}
+	_ = binary.Write(payload, binary.LittleEndian, uint32(len(offer)))
+	for _, c := range offer {
+		payload.WriteByte(byte(c))
+	}
+
+	reqID := uint64(time.Now().UnixNano())
+	if err := conn.WriteFrame(ctx, msgHello, reqID, payload.Bytes()); err != nil {
+		return compression.CodecNone, fmt.Errorf("hello: %w", err)
+	}
+
+	resp, err := conn.ReadFrame(ctx)
+	if err != nil {
+		return compression.CodecNone, fmt.Errorf("hello: %w", err)
+	}
+	if resp.MsgType == msgError {
+		return compression.CodecNone, fmt.Errorf("hello: server rejected capability exchange")
+	}
+
+	cursor := bytes.NewReader(resp.Payload)
+	var count uint32
+	_ = binary.Read(cursor, binary.LittleEndian, &count)
+	serverSupports := make(map[compression.Codec]bool, count)
+	for i := uint32(0); i < count; i++ {
+		b, err := cursor.ReadByte()
+		if err != nil {
+			break
+		}
+		serverSupports[compression.Codec(b)] = true
+	}
+
+	offered := make(map[compression.Codec]bool, len(offer))
+	for _, c := range offer {
+		offered[c] = true
+	}
+
+	for _, c := range codecPriority {
+		if offered[c] && serverSupports[c] {
+			return c, nil
+		}
+	}
+	return compression.CodecNone, nil
+}
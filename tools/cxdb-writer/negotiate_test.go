@@ -0,0 +1,135 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/strongdm/cxdb/compression"
+	"github.com/strongdm/cxdb/framing"
+)
+
+func TestParseCompressionFlag(t *testing.T) {
+	cases := []struct {
+		value string
+		want  []compression.Codec
+	}{
+		{"none", nil},
+		{"zstd", []compression.Codec{compression.CodecZstd}},
+		{"gzip", []compression.Codec{compression.CodecGzip}},
+		{"lz4", []compression.Codec{compression.CodecLZ4}},
+		{"auto", codecPriority},
+	}
+	for _, c := range cases {
+		t.Run(c.value, func(t *testing.T) {
+			got, err := parseCompressionFlag(c.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(c.want) {
+				t.Fatalf("parseCompressionFlag(%q) = %v, want %v", c.value, got, c.want)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Fatalf("parseCompressionFlag(%q) = %v, want %v", c.value, got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCompressionFlagRejectsUnknownValue(t *testing.T) {
+	if _, err := parseCompressionFlag("brotli"); err == nil {
+		t.Fatal("expected an error for an unsupported -compression value")
+	}
+}
+
+// fakeHelloServer reads one msgHello frame off conn and replies with a
+// msgHello frame advertising supports, mirroring the server side of the
+// exchange negotiateCompression drives.
+func fakeHelloServer(t *testing.T, conn net.Conn, supports []compression.Codec) {
+	t.Helper()
+
+	req, err := framing.ReadFrame(conn)
+	if err != nil {
+		t.Errorf("fake server: read hello: %v", err)
+		return
+	}
+	if req.MsgType != msgHello {
+		t.Errorf("fake server: got msg type %d, want msgHello", req.MsgType)
+		return
+	}
+
+	payload := &bytes.Buffer{}
+	_ = binary.Write(payload, binary.LittleEndian, uint32(len(supports)))
+	for _, c := range supports {
+		payload.WriteByte(byte(c))
+	}
+	if err := framing.WriteFrame(conn, msgHello, req.ReqID, payload.Bytes()); err != nil {
+		t.Errorf("fake server: write hello reply: %v", err)
+	}
+}
+
+func TestNegotiateCompressionPicksHighestPriorityMatch(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	go fakeHelloServer(t, server, []compression.Codec{compression.CodecLZ4, compression.CodecGzip})
+
+	codec, err := negotiateCompression(framing.NewConn(client), context.Background(), codecPriority)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// codecPriority prefers zstd, then gzip, then lz4; the server supports
+	// gzip and lz4 but not zstd, so gzip must win.
+	if codec != compression.CodecGzip {
+		t.Fatalf("negotiateCompression() = %v, want %v", codec, compression.CodecGzip)
+	}
+}
+
+func TestNegotiateCompressionFallsBackToNoneWhenNoOverlap(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	go fakeHelloServer(t, server, []compression.Codec{compression.CodecGzip})
+
+	codec, err := negotiateCompression(framing.NewConn(client), context.Background(), []compression.Codec{compression.CodecZstd})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if codec != compression.CodecNone {
+		t.Fatalf("negotiateCompression() = %v, want %v", codec, compression.CodecNone)
+	}
+}
+
+func TestNegotiateCompressionPropagatesServerRejection(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	go func() {
+		req, err := framing.ReadFrame(server)
+		if err != nil {
+			t.Errorf("fake server: read hello: %v", err)
+			return
+		}
+		if err := framing.WriteFrame(server, msgError, req.ReqID, []byte("nope")); err != nil {
+			t.Errorf("fake server: write error reply: %v", err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if _, err := negotiateCompression(framing.NewConn(client), ctx, codecPriority); err == nil {
+		t.Fatal("expected an error when the server rejects the capability exchange")
+	}
+}
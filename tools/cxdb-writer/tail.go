@@ -0,0 +1,161 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/strongdm/cxdb/framing"
+	"github.com/strongdm/cxdb/watch"
+)
+
+func cmdTail(args []string) {
+	fs := flag.NewFlagSet("tail", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:9009", "server address")
+	contextID := fs.Uint64("context", 0, "context id")
+	fromTurn := fs.Uint64("from-turn", 0, "resume point (turn id); 0 starts at the current head")
+	typeFilter := fs.String("type-filter", "", "comma-separated list of type ids to include (default: all)")
+	creditEvery := fs.Uint("credit-every", 32, "send a flow credit frame after this many turn events (0 disables flow control)")
+	timeout := fs.Duration("timeout", 10*time.Second, "timeout for the initial subscribe handshake (0 disables it)")
+	tlsOpts := addTLSFlags(fs)
+	fs.Parse(args)
+
+	if *contextID == 0 {
+		fmt.Println("context is required")
+		os.Exit(1)
+	}
+
+	tlsConfig, err := tlsOpts.config()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var filters []string
+	if *typeFilter != "" {
+		filters = strings.Split(*typeFilter, ",")
+	}
+
+	payload := &bytes.Buffer{}
+	_ = binary.Write(payload, binary.LittleEndian, *contextID)
+	_ = binary.Write(payload, binary.LittleEndian, *fromTurn)
+	_ = binary.Write(payload, binary.LittleEndian, uint32(len(filters)))
+	for _, t := range filters {
+		_ = binary.Write(payload, binary.LittleEndian, uint32(len(t)))
+		payload.WriteString(t)
+	}
+	_ = binary.Write(payload, binary.LittleEndian, uint32(*creditEvery))
+
+	handshakeCtx, cancel := framing.CallContext(*timeout)
+	defer cancel()
+
+	conn := framing.NewConn(mustDial(*addr, tlsConfig))
+	defer conn.Close()
+
+	reqID := uint64(time.Now().UnixNano())
+	if err := conn.WriteFrame(handshakeCtx, msgSubscribe, reqID, payload.Bytes()); err != nil {
+		fmt.Println("write error:", err)
+		os.Exit(1)
+	}
+
+	// The subscription itself is unbounded: once opened it runs until the
+	// server ends it or the process is interrupted, so frames past the
+	// handshake are read without a per-call deadline.
+	streamCtx := context.Background()
+
+	dispatcher := watch.NewDispatcher(watch.Options{
+		FromDepth:    *fromTurn,
+		TypeFilter:   filters,
+		Backpressure: watch.BackpressureBlock,
+	})
+
+	go func() {
+		defer dispatcher.Close()
+		for {
+			resp, err := conn.ReadFrame(streamCtx)
+			if err != nil {
+				fmt.Println("read error:", err)
+				os.Exit(1)
+			}
+
+			switch resp.MsgType {
+			case msgError:
+				fatalError(resp.Payload)
+			case msgSubscribeEnd:
+				fmt.Println("subscription ended")
+				return
+			case msgTurnEvent:
+				ev, err := decodeTurnEvent(resp.Payload)
+				if err != nil {
+					fmt.Println("turn event error:", err)
+					continue
+				}
+				dispatcher.Dispatch(ev)
+			default:
+				fmt.Printf("unexpected frame type %d\n", resp.MsgType)
+			}
+		}
+	}()
+
+	var pending uint32
+	for ev := range dispatcher.Events() {
+		printTurnEvent(ev)
+
+		pending++
+		if *creditEvery > 0 && pending >= uint32(*creditEvery) {
+			if err := sendFlowCredit(conn, streamCtx, pending); err != nil {
+				fmt.Println("flow credit error:", err)
+				os.Exit(1)
+			}
+			pending = 0
+		}
+	}
+}
+
+// decodeTurnEvent decodes a msgTurnEvent payload (a kind byte followed by
+// the shared turn record) into the watch package's TurnEvent contract.
+func decodeTurnEvent(raw []byte) (watch.TurnEvent, error) {
+	if len(raw) < 1 {
+		return watch.TurnEvent{}, fmt.Errorf("malformed turn event")
+	}
+	kind := watch.EventKind(raw[0])
+	cursor := bytes.NewReader(raw[1:])
+	t := decodeTurn(cursor)
+	payload, err := t.decompressedPayload()
+	if err != nil {
+		return watch.TurnEvent{}, fmt.Errorf("turn_id=%d: decompress error: %w", t.turnID, err)
+	}
+	return watch.TurnEvent{
+		Kind: kind,
+		Turn: watch.Turn{
+			TurnID:      t.turnID,
+			ParentID:    t.parentID,
+			Depth:       t.depth,
+			TypeID:      t.typeID,
+			TypeVersion: t.typeVersion,
+			PayloadHash: t.hash,
+			Payload:     payload,
+		},
+	}, nil
+}
+
+func printTurnEvent(ev watch.TurnEvent) {
+	fmt.Printf("[%s] turn_id=%d depth=%d type=%s v%d len=%d\n",
+		ev.Kind, ev.Turn.TurnID, ev.Turn.Depth, ev.Turn.TypeID, ev.Turn.TypeVersion, len(ev.Turn.Payload))
+}
+
+// sendFlowCredit acks `count` processed turn events, telling the server it
+// may send that many more before a slow reader applies backpressure.
+func sendFlowCredit(conn *framing.Conn, ctx context.Context, count uint32) error {
+	payload := &bytes.Buffer{}
+	_ = binary.Write(payload, binary.LittleEndian, count)
+	return conn.WriteFrame(ctx, msgFlowCredit, uint64(time.Now().UnixNano()), payload.Bytes())
+}
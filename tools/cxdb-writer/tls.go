@@ -0,0 +1,97 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// tlsFlags are the -tls-* flags shared by every binary-protocol subcommand.
+// TLS must be opted into explicitly via -tls; it is never inferred from the
+// destination address.
+type tlsFlags struct {
+	enable     *bool
+	ca         *string
+	cert       *string
+	key        *string
+	serverName *string
+	insecure   *bool
+}
+
+func addTLSFlags(fs *flag.FlagSet) *tlsFlags {
+	return &tlsFlags{
+		enable:     fs.Bool("tls", false, "connect over TLS"),
+		ca:         fs.String("tls-ca", "", "path to a PEM CA/trust bundle used to verify the peer"),
+		cert:       fs.String("tls-cert", "", "path to a PEM client certificate for mutual TLS"),
+		key:        fs.String("tls-key", "", "path to the PEM private key matching -tls-cert"),
+		serverName: fs.String("tls-server-name", "", "override the server name used for SNI and certificate verification"),
+		insecure:   fs.Bool("tls-insecure", false, "skip server certificate verification (development only)"),
+	}
+}
+
+// config builds a *tls.Config from the parsed flags, or returns nil if -tls
+// was not set, meaning the connection stays plaintext.
+func (f *tlsFlags) config() (*tls.Config, error) {
+	if !*f.enable {
+		return nil, nil
+	}
+	return LoadTLSConfig(TLSConfigOptions{
+		CAPath:             *f.ca,
+		CertPath:           *f.cert,
+		KeyPath:            *f.key,
+		ServerName:         *f.serverName,
+		InsecureSkipVerify: *f.insecure,
+	})
+}
+
+// TLSConfigOptions describes how to build a *tls.Config for a connection,
+// covering both server verification (CAPath, ServerName) and mutual TLS
+// (CertPath, KeyPath).
+type TLSConfigOptions struct {
+	CAPath             string
+	CertPath           string
+	KeyPath            string
+	ServerName         string
+	InsecureSkipVerify bool
+}
+
+// LoadTLSConfig loads a keypair and trust bundle once into a *tls.Config
+// that callers can reuse across dials instead of rebuilding it per
+// connection.
+func LoadTLSConfig(opts TLSConfigOptions) (*tls.Config, error) {
+	cfg := &tls.Config{
+		ServerName:         opts.ServerName,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+
+	if opts.CAPath != "" {
+		pem, err := os.ReadFile(opts.CAPath)
+		if err != nil {
+			return nil, fmt.Errorf("tls: read ca bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("tls: no certificates found in %s", opts.CAPath)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if opts.CertPath != "" || opts.KeyPath != "" {
+		if opts.CertPath == "" || opts.KeyPath == "" {
+			return nil, errors.New("tls: -tls-cert and -tls-key must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(opts.CertPath, opts.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("tls: load client keypair: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
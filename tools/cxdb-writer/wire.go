@@ -0,0 +1,62 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/strongdm/cxdb/compression"
+)
+
+// decodedTurn is the decoded form of the per-turn record shared by
+// msgGetLast's response and msgTurnEvent's payload.
+type decodedTurn struct {
+	turnID          uint64
+	parentID        uint64
+	depth           uint32
+	typeID          string
+	typeVersion     uint32
+	encoding        uint32
+	compression     uint32
+	uncompressedLen uint32
+	hash            [32]byte
+	payload         []byte
+}
+
+// decodeTurn reads one turn record from cursor in the wire format shared by
+// msgGetLast responses and msgTurnEvent payloads. cmdTail is its caller;
+// cmdGetLast goes through CxdbService instead.
+func decodeTurn(cursor *bytes.Reader) decodedTurn {
+	var t decodedTurn
+	_ = binary.Read(cursor, binary.LittleEndian, &t.turnID)
+	_ = binary.Read(cursor, binary.LittleEndian, &t.parentID)
+	_ = binary.Read(cursor, binary.LittleEndian, &t.depth)
+
+	var typeLen uint32
+	_ = binary.Read(cursor, binary.LittleEndian, &typeLen)
+	typeBytes := make([]byte, typeLen)
+	_, _ = cursor.Read(typeBytes)
+	t.typeID = string(typeBytes)
+
+	_ = binary.Read(cursor, binary.LittleEndian, &t.typeVersion)
+	_ = binary.Read(cursor, binary.LittleEndian, &t.encoding)
+	_ = binary.Read(cursor, binary.LittleEndian, &t.compression)
+	_ = binary.Read(cursor, binary.LittleEndian, &t.uncompressedLen)
+	_, _ = cursor.Read(t.hash[:])
+
+	var payloadLen uint32
+	_ = binary.Read(cursor, binary.LittleEndian, &payloadLen)
+	t.payload = make([]byte, payloadLen)
+	_, _ = cursor.Read(t.payload)
+
+	return t
+}
+
+// decompressedPayload returns t.payload run through the codec declared in
+// its compression field, transparently reversing whatever cmdAppend
+// applied before sending it.
+func (t decodedTurn) decompressedPayload() ([]byte, error) {
+	return compression.Decompress(compression.Codec(t.compression), t.payload)
+}
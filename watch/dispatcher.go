@@ -0,0 +1,94 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import "sync/atomic"
+
+const defaultBufferSize = 64
+
+// Dispatcher fans TurnEvents from a connection's read loop into a channel
+// that honors Options.Backpressure and Options.TypeFilter, and tracks the
+// last depth delivered so a reconnect can resume with FromDepth instead of
+// replaying from the start.
+//
+// A Dispatcher is built once per subscription and discarded on
+// unsubscribe; it is not reused across reconnects.
+type Dispatcher struct {
+	opts      Options
+	typeSet   map[string]struct{}
+	ch        chan TurnEvent
+	lastDepth uint64
+}
+
+// NewDispatcher creates a Dispatcher for a subscription opened with opts.
+func NewDispatcher(opts Options) *Dispatcher {
+	size := opts.BufferSize
+	if size <= 0 {
+		size = defaultBufferSize
+	}
+
+	var typeSet map[string]struct{}
+	if len(opts.TypeFilter) > 0 {
+		typeSet = make(map[string]struct{}, len(opts.TypeFilter))
+		for _, t := range opts.TypeFilter {
+			typeSet[t] = struct{}{}
+		}
+	}
+
+	return &Dispatcher{
+		opts:      opts,
+		typeSet:   typeSet,
+		ch:        make(chan TurnEvent, size),
+		lastDepth: opts.FromDepth,
+	}
+}
+
+// Events returns the channel consumers should range over.
+func (d *Dispatcher) Events() <-chan TurnEvent {
+	return d.ch
+}
+
+// ResumeFrom returns the depth a reconnect should request via
+// Options.FromDepth: the depth of the last event actually delivered to the
+// channel.
+func (d *Dispatcher) ResumeFrom() uint64 {
+	return atomic.LoadUint64(&d.lastDepth)
+}
+
+// Close releases the underlying channel. Callers must stop calling
+// Dispatch before calling Close.
+func (d *Dispatcher) Close() {
+	close(d.ch)
+}
+
+// Dispatch delivers ev according to the configured TypeFilter and
+// Backpressure policy. It is intended to be called from a single read-loop
+// goroutine per subscription.
+func (d *Dispatcher) Dispatch(ev TurnEvent) {
+	if d.typeSet != nil {
+		if _, ok := d.typeSet[ev.Turn.TypeID]; !ok {
+			return
+		}
+	}
+
+	switch d.opts.Backpressure {
+	case BackpressureDropOldest:
+		for {
+			select {
+			case d.ch <- ev:
+			default:
+				select {
+				case <-d.ch:
+				default:
+				}
+				continue
+			}
+			break
+		}
+	default: // BackpressureBlock
+		d.ch <- ev
+	}
+
+	atomic.StoreUint64(&d.lastDepth, uint64(ev.Turn.Depth))
+}
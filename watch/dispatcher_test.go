@@ -0,0 +1,136 @@
+// Copyright 2025 StrongDM Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDispatchBlockDeliversEveryEvent(t *testing.T) {
+	d := NewDispatcher(Options{BufferSize: 2})
+
+	go func() {
+		for i := 1; i <= 5; i++ {
+			d.Dispatch(TurnEvent{Turn: Turn{TurnID: uint64(i), Depth: uint32(i)}})
+		}
+		d.Close()
+	}()
+
+	var got []uint64
+	for ev := range d.Events() {
+		got = append(got, ev.Turn.TurnID)
+	}
+	if len(got) != 5 {
+		t.Fatalf("got %d events, want 5", len(got))
+	}
+	for i, id := range got {
+		if id != uint64(i+1) {
+			t.Errorf("event %d has TurnID %d, want %d", i, id, i+1)
+		}
+	}
+}
+
+func TestDispatchDropOldestDiscardsBackloggedEvents(t *testing.T) {
+	d := NewDispatcher(Options{BufferSize: 1, Backpressure: BackpressureDropOldest})
+
+	// Dispatch faster than anything drains the channel: with a buffer of
+	// 1, each new event should evict whatever was sitting in the channel
+	// instead of blocking the caller.
+	for i := 1; i <= 10; i++ {
+		d.Dispatch(TurnEvent{Turn: Turn{TurnID: uint64(i), Depth: uint32(i)}})
+	}
+
+	select {
+	case ev := <-d.Events():
+		if ev.Turn.TurnID != 10 {
+			t.Errorf("buffered event has TurnID %d, want the most recent (10)", ev.Turn.TurnID)
+		}
+	default:
+		t.Fatal("expected the most recent event to be buffered")
+	}
+}
+
+func TestDispatchTypeFilterExcludesNonMatchingTurns(t *testing.T) {
+	d := NewDispatcher(Options{BufferSize: 4, TypeFilter: []string{"wanted"}})
+
+	d.Dispatch(TurnEvent{Turn: Turn{TurnID: 1, TypeID: "other", Depth: 1}})
+	d.Dispatch(TurnEvent{Turn: Turn{TurnID: 2, TypeID: "wanted", Depth: 2}})
+	d.Close()
+
+	var got []uint64
+	for ev := range d.Events() {
+		got = append(got, ev.Turn.TurnID)
+	}
+	if len(got) != 1 || got[0] != 2 {
+		t.Fatalf("got %v, want only turn 2 (matching the type filter)", got)
+	}
+}
+
+func TestDispatchNoFilterAllowsEverything(t *testing.T) {
+	d := NewDispatcher(Options{BufferSize: 2})
+	d.Dispatch(TurnEvent{Turn: Turn{TurnID: 1, TypeID: "anything", Depth: 1}})
+	d.Close()
+
+	ev, ok := <-d.Events()
+	if !ok {
+		t.Fatal("expected an event, channel was closed empty")
+	}
+	if ev.Turn.TurnID != 1 {
+		t.Errorf("TurnID = %d, want 1", ev.Turn.TurnID)
+	}
+}
+
+func TestResumeFromTracksLastDeliveredDepth(t *testing.T) {
+	d := NewDispatcher(Options{FromDepth: 7, BufferSize: 4})
+
+	if got := d.ResumeFrom(); got != 7 {
+		t.Fatalf("ResumeFrom before any dispatch = %d, want the configured FromDepth 7", got)
+	}
+
+	d.Dispatch(TurnEvent{Turn: Turn{TurnID: 1, Depth: 8}})
+	d.Dispatch(TurnEvent{Turn: Turn{TurnID: 2, Depth: 9}})
+
+	// Drain so Dispatch (BackpressureBlock, buffer of 4) never blocks.
+	<-d.Events()
+	<-d.Events()
+
+	if got := d.ResumeFrom(); got != 9 {
+		t.Fatalf("ResumeFrom after dispatch = %d, want 9", got)
+	}
+}
+
+func TestEventKindString(t *testing.T) {
+	cases := map[EventKind]string{
+		EventAppended: "appended",
+		EventAttached: "attached",
+		EventForked:   "forked",
+		EventKind(99): "unknown",
+	}
+	for k, want := range cases {
+		if got := k.String(); got != want {
+			t.Errorf("EventKind(%d).String() = %q, want %q", k, got, want)
+		}
+	}
+}
+
+// TestDispatchDropOldestDoesNotBlock guards the DropOldest policy's central
+// guarantee: a producer never blocks on a slow/absent consumer.
+func TestDispatchDropOldestDoesNotBlock(t *testing.T) {
+	d := NewDispatcher(Options{BufferSize: 1, Backpressure: BackpressureDropOldest})
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 1000; i++ {
+			d.Dispatch(TurnEvent{Turn: Turn{TurnID: uint64(i)}})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Dispatch with BackpressureDropOldest blocked with no consumer draining the channel")
+	}
+}